@@ -29,33 +29,25 @@ import (
 
 	"github.com/jenkins-x/jx/pkg/extensions"
 
+	"github.com/jenkins-x/jx/pkg/jx/i18n"
 	"github.com/jenkins-x/jx/pkg/log"
 
 	"github.com/jenkins-x/jx/pkg/jx/cmd/clients"
+	"github.com/jenkins-x/jx/pkg/jx/cmd/plugin"
 	"github.com/jenkins-x/jx/pkg/jx/cmd/templates"
+	"github.com/jenkins-x/jx/pkg/util"
 	"github.com/jenkins-x/jx/pkg/version"
 	"github.com/spf13/cobra"
 	"gopkg.in/AlecAivazis/survey.v1/terminal"
 )
 
-const (
-	//     * runs (aka 'run')
-
-	valid_resources = `Valid resource types include:
-
-    * environments (aka 'env')
-    * pipelines (aka 'pipe')
-    * urls (aka 'url')
-    `
-)
-
 // NewJXCommand creates the `jx` command and its nested children.
 // args used to determine binary plugin to run can be overridden (does not affect compiled in commands).
 func NewJXCommand(f clients.Factory, in terminal.FileReader, out terminal.FileWriter,
 	err io.Writer, args []string) *cobra.Command {
 	cmds := &cobra.Command{
 		Use:   "jx",
-		Short: "jx is a command line tool for working with Jenkins X",
+		Short: i18n.T("jx is a command line tool for working with Jenkins X"),
 		Run:   runHelp,
 	}
 
@@ -68,6 +60,7 @@ func NewJXCommand(f clients.Factory, in terminal.FileReader, out terminal.FileWr
 
 	// commonOpts holds the global flags that will be shared/inherited by all sub-commands created bellow
 	commonOpts.addCommonFlags(cmds)
+	cmds.PersistentFlags().String("plugins", "", "Selects which plugin providers handleEndpointExtensions consults and in what order, e.g. 'local' or 'managed,local'. A version suffix such as 'managed:v2' is accepted and saved as the project's plugin key, but lookup uses the provider name only")
 
 	addCommands := NewCmdAdd(commonOpts)
 	createCommands := NewCmdCreate(commonOpts)
@@ -112,23 +105,23 @@ func NewJXCommand(f clients.Factory, in terminal.FileReader, out terminal.FileWr
 
 	groups := templates.CommandGroups{
 		{
-			Message:  "Installing:",
+			Message:  i18n.T("Installing:"),
 			Commands: installCommands,
 		},
 		{
-			Message:  "Adding Projects to Jenkins X:",
+			Message:  i18n.T("Adding Projects to Jenkins X:"),
 			Commands: addProjectCommands,
 		},
 		{
-			Message:  "Apps:",
+			Message:  i18n.T("Apps:"),
 			Commands: addonCommands,
 		},
 		{
-			Message:  "Git:",
+			Message:  i18n.T("Git:"),
 			Commands: gitCommands,
 		},
 		{
-			Message: "Working with Kubernetes:",
+			Message: i18n.T("Working with Kubernetes:"),
 			Commands: []*cobra.Command{
 				NewCompliance(commonOpts),
 				NewCmdCompletion(commonOpts),
@@ -143,7 +136,7 @@ func NewJXCommand(f clients.Factory, in terminal.FileReader, out terminal.FileWr
 			},
 		},
 		{
-			Message: "Working with Applications:",
+			Message: i18n.T("Working with Applications:"),
 			Commands: []*cobra.Command{
 				NewCmdConsole(commonOpts),
 				NewCmdLogs(commonOpts),
@@ -153,18 +146,18 @@ func NewJXCommand(f clients.Factory, in terminal.FileReader, out terminal.FileWr
 			},
 		},
 		{
-			Message: "Working with CloudBees application:",
+			Message: i18n.T("Working with CloudBees application:"),
 			Commands: []*cobra.Command{
 				NewCmdCloudBees(commonOpts),
 				NewCmdLogin(commonOpts),
 			},
 		},
 		{
-			Message:  "Working with Environments:",
+			Message:  i18n.T("Working with Environments:"),
 			Commands: environmentsCommands,
 		},
 		{
-			Message: "Working with Jenkins X resources:",
+			Message: i18n.T("Working with Jenkins X resources:"),
 			Commands: []*cobra.Command{
 				getCommands,
 				editCommands,
@@ -177,18 +170,24 @@ func NewJXCommand(f clients.Factory, in terminal.FileReader, out terminal.FileWr
 			},
 		},
 		{
-			Message: "Jenkins X Pipeline Commands:",
+			Message: i18n.T("Jenkins X Pipeline Commands:"),
 			Commands: []*cobra.Command{
 				NewCmdStep(commonOpts),
 			},
 		},
 		{
-			Message: "Jenkins X services:",
+			Message: i18n.T("Jenkins X services:"),
 			Commands: []*cobra.Command{
 				NewCmdController(commonOpts),
 				NewCmdGC(commonOpts),
 			},
 		},
+		{
+			Message: i18n.T("Managing Plugins:"),
+			Commands: []*cobra.Command{
+				NewCmdPlugin(commonOpts),
+			},
+		},
 	}
 
 	groups.Add(cmds)
@@ -213,11 +212,18 @@ func NewJXCommand(f clients.Factory, in terminal.FileReader, out terminal.FileWr
 	cmds.SetVersionTemplate("{{printf .Version}}\n")
 	cmds.AddCommand(NewCmdOptions(out))
 	cmds.AddCommand(NewCmdDiagnose(commonOpts))
+	cmds.AddCommand(NewCmdRepl(commonOpts))
 
 	managedPlugins := &managedPluginHandler{
 		CommonOptions: commonOpts,
 	}
 	localPlugins := &localPluginHandler{}
+	handlers := map[string]plugin.ProviderHandler{"local": localPlugins}
+	defaultOrder := []string{"local"}
+	if dir, err := util.ConfigDir(); err == nil {
+		handlers["cached"] = plugin.NewManager(dir, "")
+		defaultOrder = append([]string{"cached"}, defaultOrder...)
+	}
 
 	if len(args) == 0 {
 		args = os.Args
@@ -229,23 +235,75 @@ func NewJXCommand(f clients.Factory, in terminal.FileReader, out terminal.FileWr
 		// the specified command does not already exist
 		if _, _, err := cmds.Find(cmdPathPieces); err != nil {
 			if _, managedPluginsEnabled := getPluginCommandGroups(); managedPluginsEnabled {
-				if err := handleEndpointExtensions(managedPlugins, cmdPathPieces); err != nil {
-					log.Errorf("%v\n", err)
-					os.Exit(1)
-				}
-			} else {
-				if err := handleEndpointExtensions(localPlugins, cmdPathPieces); err != nil {
-					log.Errorf("%v\n", err)
-					os.Exit(1)
-				}
+				handlers["managed"] = managedPlugins
+				defaultOrder = append([]string{"managed"}, defaultOrder...)
 			}
 
+			// --plugins on the command line wins; failing that, fall back to
+			// whichever provider last scaffolded this project (see plugin.SaveLayoutKey).
+			rawPlugins := parsePluginsFlag(args)
+			if rawPlugins == "" {
+				rawPlugins = plugin.LoadLayoutKey(".")
+			}
+
+			resolver, err := plugin.NewResolver(rawPlugins, handlers, plugin.WithDefaultPlugins(defaultOrder...))
+			if err != nil {
+				log.Errorf("%v\n", err)
+				os.Exit(1)
+			}
+
+			if err := handleEndpointExtensions(&resolverPluginHandler{resolver: resolver}, cmdPathPieces); err != nil {
+				log.Errorf("%v\n", err)
+				os.Exit(1)
+			}
 		}
 	}
 
 	return cmds
 }
 
+// parsePluginsFlag pre-scans args for --plugins/--plugins=value, mirroring
+// the way cmdPathPieces above is derived before cobra gets a chance to
+// parse flags itself.
+func parsePluginsFlag(args []string) string {
+	for i, arg := range args {
+		if strings.HasPrefix(arg, "--plugins=") {
+			return strings.TrimPrefix(arg, "--plugins=")
+		}
+		if arg == "--plugins" && i+1 < len(args) {
+			return args[i+1]
+		}
+	}
+	return ""
+}
+
+// resolverPluginHandler adapts a plugin.Resolver (Lookup only) to the
+// PluginHandler interface expected by handleEndpointExtensions, delegating
+// Execute to the local $PATH the same way managedPluginHandler does.
+type resolverPluginHandler struct {
+	resolver *plugin.Resolver
+	localPluginHandler
+}
+
+// Lookup implements PluginHandler. On success it also persists which
+// provider resolved filename, since handleEndpointExtensions' subsequent
+// Execute call replaces the process image (or exits) and never returns
+// control to a caller that could persist it afterwards.
+func (h *resolverPluginHandler) Lookup(filename string) (string, error) {
+	path, err := h.resolver.Lookup(filename)
+	if err != nil {
+		return "", err
+	}
+
+	if p := h.resolver.LastProvider(); p.Name != "" {
+		if err := plugin.SaveLayoutKey(".", p.Key()); err != nil {
+			log.Warnf("failed to persist plugin provider %s: %v\n", p.Key(), err)
+		}
+	}
+
+	return path, nil
+}
+
 func findCommands(subCommand string, commands ...*cobra.Command) []*cobra.Command {
 	answer := []*cobra.Command{}
 	for _, parent := range commands {
@@ -313,15 +371,16 @@ func (h *managedPluginHandler) Lookup(filename string) (string, error) {
 	if err != nil {
 		return "", err
 	}
-	if len(possibles.Items) > 0 {
-		found := possibles.Items[0]
-		if len(possibles.Items) > 1 {
-			// There is a warning about this when you install extensions as well
-			log.Warnf("More than one plugin installed for %s by apps. Selecting the one installed by %s at random.\n",
-				filename, found.Name)
-
+	if len(possibles.Items) > 1 {
+		names := make([]string, len(possibles.Items))
+		for i, p := range possibles.Items {
+			names[i] = p.Name
 		}
-		return extensions.EnsurePluginInstalled(found)
+		return "", fmt.Errorf(i18n.T("ambiguous plugin %s: more than one Plugin resource installed it (%s); uninstall all but one to continue"),
+			filename, strings.Join(names, ", "))
+	}
+	if len(possibles.Items) == 1 {
+		return extensions.EnsurePluginInstalled(possibles.Items[0])
 	}
 	return h.localPluginHandler.Lookup(filename)
 }
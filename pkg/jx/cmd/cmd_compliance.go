@@ -0,0 +1,148 @@
+/*
+Copyright 2018 The Kubernetes Authors & The Jenkins X Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"fmt"
+	"os/exec"
+	"strings"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/jenkins-x/jx/pkg/jx/cmd/templates"
+	"github.com/jenkins-x/jx/pkg/jx/i18n"
+	"github.com/spf13/cobra"
+)
+
+// ComplianceOptions contains the command line options for `jx compliance`
+type ComplianceOptions struct {
+	CommonOptions
+}
+
+var (
+	compliance_long = templates.LongDesc(i18n.T(`
+		Runs compliance checks against the current cluster: kube-bench, and a
+		check that every namespace has at least one NetworkPolicy.`))
+
+	compliance_example = templates.Examples(i18n.T(`
+		# Run the compliance checks
+		jx compliance run
+
+		# Run the compliance checks and emit a JUnit report for CI
+		jx compliance run --report junit:reports/compliance.xml`))
+)
+
+// NewCompliance creates the `jx compliance` command
+func NewCompliance(commonOpts *CommonOptions) *cobra.Command {
+	options := &ComplianceOptions{
+		CommonOptions: *commonOpts,
+	}
+
+	cmd := &cobra.Command{
+		Use:     "compliance",
+		Short:   i18n.T("Checks the compliance of the current cluster"),
+		Long:    compliance_long,
+		Example: compliance_example,
+		Run:     runHelp,
+	}
+
+	runCmd := &cobra.Command{
+		Use:   "run",
+		Short: i18n.T("Runs the compliance checks"),
+		Run: func(cmd *cobra.Command, args []string) {
+			options.Cmd = cmd
+			options.Args = args
+			err := options.Run()
+			CheckErr(err)
+		},
+	}
+	options.addReportFlags(runCmd)
+	cmd.AddCommand(runCmd)
+	return cmd
+}
+
+// Run implements this command
+func (o *ComplianceOptions) Run() error {
+	report, err := o.CreateReportWriter("compliance")
+	if err != nil {
+		return err
+	}
+	defer o.Flush(report)
+
+	checks := []struct {
+		name string
+		fn   func() error
+	}{
+		{"kube-bench", o.runKubeBench},
+		{"network-policies", o.checkNetworkPolicies},
+	}
+
+	var firstErr error
+	for _, check := range checks {
+		if err := ReportStep(&o.CommonOptions, report, check.name, check.fn); err != nil && firstErr == nil {
+			firstErr = err
+		}
+	}
+	return firstErr
+}
+
+// runKubeBench shells out to the kube-bench binary (if it's on $PATH) and
+// fails the step if it reports any "[FAIL]" results.
+func (o *ComplianceOptions) runKubeBench() error {
+	path, err := exec.LookPath("kube-bench")
+	if err != nil {
+		return fmt.Errorf("kube-bench is not installed: %v", err)
+	}
+
+	out, runErr := exec.Command(path).CombinedOutput()
+	fmt.Fprint(o.Out, string(out))
+
+	failures := strings.Count(string(out), "[FAIL]")
+	if failures > 0 {
+		return fmt.Errorf("kube-bench reported %d failed check(s)", failures)
+	}
+	return runErr
+}
+
+// checkNetworkPolicies fails the step if any namespace has no NetworkPolicy defined.
+func (o *ComplianceOptions) checkNetworkPolicies() error {
+	kubeClient, _, err := o.KubeClientAndNamespace()
+	if err != nil {
+		return err
+	}
+
+	namespaces, err := kubeClient.CoreV1().Namespaces().List(metav1.ListOptions{})
+	if err != nil {
+		return err
+	}
+
+	missing := []string{}
+	for _, namespace := range namespaces.Items {
+		policies, err := kubeClient.NetworkingV1().NetworkPolicies(namespace.Name).List(metav1.ListOptions{})
+		if err != nil {
+			return err
+		}
+		if len(policies.Items) == 0 {
+			missing = append(missing, namespace.Name)
+		}
+	}
+
+	if len(missing) > 0 {
+		return fmt.Errorf("namespace(s) %s have no NetworkPolicy defined", strings.Join(missing, ", "))
+	}
+	return nil
+}
@@ -0,0 +1,121 @@
+/*
+Copyright 2018 The Kubernetes Authors & The Jenkins X Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"fmt"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/jenkins-x/jx/pkg/jx/cmd/templates"
+	"github.com/jenkins-x/jx/pkg/jx/i18n"
+	"github.com/spf13/cobra"
+)
+
+// DiagnoseOptions contains the command line options for `jx diagnose`
+type DiagnoseOptions struct {
+	CommonOptions
+}
+
+var (
+	diagnose_long = templates.LongDesc(i18n.T(`
+		Gathers diagnostic information about the current Jenkins X install.`))
+
+	diagnose_example = templates.Examples(i18n.T(`
+		# Gather diagnostic information
+		jx diagnose
+
+		# Gather diagnostic information and emit a JUnit report for CI
+		jx diagnose --report junit:reports/diagnose.xml`))
+)
+
+// NewCmdDiagnose creates the `jx diagnose` command
+func NewCmdDiagnose(commonOpts *CommonOptions) *cobra.Command {
+	options := &DiagnoseOptions{
+		CommonOptions: *commonOpts,
+	}
+
+	cmd := &cobra.Command{
+		Use:     "diagnose",
+		Short:   i18n.T("Gathers diagnostic information about the current Jenkins X install"),
+		Long:    diagnose_long,
+		Example: diagnose_example,
+		Run: func(cmd *cobra.Command, args []string) {
+			options.Cmd = cmd
+			options.Args = args
+			err := options.Run()
+			CheckErr(err)
+		},
+	}
+	options.addReportFlags(cmd)
+	return cmd
+}
+
+// Run implements this command
+func (o *DiagnoseOptions) Run() error {
+	report, err := o.CreateReportWriter("diagnose")
+	if err != nil {
+		return err
+	}
+	defer o.Flush(report)
+
+	steps := []struct {
+		name string
+		fn   func() error
+	}{
+		{"kube-context", o.diagnoseKubeContext},
+		{"dev-namespace", o.diagnoseDevNamespace},
+		{"jx-pods", o.diagnosePods},
+	}
+
+	var firstErr error
+	for _, step := range steps {
+		if err := ReportStep(&o.CommonOptions, report, step.name, step.fn); err != nil && firstErr == nil {
+			firstErr = err
+		}
+	}
+	return firstErr
+}
+
+func (o *DiagnoseOptions) diagnoseKubeContext() error {
+	_, _, err := o.KubeClientAndNamespace()
+	return err
+}
+
+func (o *DiagnoseOptions) diagnoseDevNamespace() error {
+	_, _, err := o.JXClientAndDevNamespace()
+	return err
+}
+
+func (o *DiagnoseOptions) diagnosePods() error {
+	kubeClient, ns, err := o.KubeClientAndNamespace()
+	if err != nil {
+		return err
+	}
+
+	pods, err := kubeClient.CoreV1().Pods(ns).List(metav1.ListOptions{})
+	if err != nil {
+		return err
+	}
+
+	for _, pod := range pods.Items {
+		if pod.Status.Phase != "Running" && pod.Status.Phase != "Succeeded" {
+			fmt.Fprintf(o.Out, "pod %s is %s\n", pod.Name, pod.Status.Phase)
+		}
+	}
+	return nil
+}
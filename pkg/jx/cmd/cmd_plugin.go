@@ -0,0 +1,320 @@
+/*
+Copyright 2018 The Kubernetes Authors & The Jenkins X Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"fmt"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/jenkins-x/jx/pkg/jx/cmd/plugin"
+	"github.com/jenkins-x/jx/pkg/jx/cmd/templates"
+	"github.com/jenkins-x/jx/pkg/jx/i18n"
+	"github.com/jenkins-x/jx/pkg/util"
+	"github.com/spf13/cobra"
+)
+
+// PluginOptions contains the command line options for `jx plugin`
+type PluginOptions struct {
+	CommonOptions
+
+	IndexURL string
+}
+
+var (
+	plugin_long = templates.LongDesc(i18n.T(`
+		Lists, installs and describes jx plugins.
+
+		Plugins can either be installed locally (and found on the $PATH as jx-<name>)
+		or managed for the team via the cluster's Plugins custom resource.`))
+)
+
+// NewCmdPlugin creates the `jx plugin` command and its list/install/uninstall/describe children
+func NewCmdPlugin(commonOpts *CommonOptions) *cobra.Command {
+	options := &PluginOptions{
+		CommonOptions: *commonOpts,
+	}
+
+	cmd := &cobra.Command{
+		Use:     "plugin",
+		Short:   i18n.T("Manages jx plugins"),
+		Long:    plugin_long,
+		Run:     options.Run,
+		Aliases: []string{"plugins"},
+	}
+
+	cmd.AddCommand(NewCmdPluginList(commonOpts))
+	cmd.AddCommand(NewCmdPluginInstall(commonOpts))
+	cmd.AddCommand(NewCmdPluginUninstall(commonOpts))
+	cmd.AddCommand(NewCmdPluginDescribe(commonOpts))
+	return cmd
+}
+
+// Run implements this command
+func (o *PluginOptions) Run(cmd *cobra.Command, args []string) {
+	cmd.Help()
+}
+
+// pluginManager returns a plugin.Manager rooted in the user's jx home directory
+func (o *PluginOptions) pluginManager() (*plugin.Manager, error) {
+	dir, err := util.ConfigDir()
+	if err != nil {
+		return nil, fmt.Errorf("failed to find jx config dir: %v", err)
+	}
+	return plugin.NewManager(dir, o.IndexURL), nil
+}
+
+// PluginListOptions contains the command line options for `jx plugin list`
+type PluginListOptions struct {
+	PluginOptions
+}
+
+var (
+	pluginList_long = templates.LongDesc(i18n.T(`
+		Lists the plugins installed locally and, where a Kubernetes connection is
+		available, those managed by the team's Plugins custom resource.`))
+
+	pluginList_example = templates.Examples(i18n.T(`
+		# List all available plugins
+		jx plugin list`))
+)
+
+// NewCmdPluginList creates the `jx plugin list` command
+func NewCmdPluginList(commonOpts *CommonOptions) *cobra.Command {
+	options := &PluginListOptions{
+		PluginOptions: PluginOptions{CommonOptions: *commonOpts},
+	}
+
+	cmd := &cobra.Command{
+		Use:     "list",
+		Short:   i18n.T("Lists the available and installed plugins"),
+		Long:    pluginList_long,
+		Example: pluginList_example,
+		Run: func(cmd *cobra.Command, args []string) {
+			options.Cmd = cmd
+			options.Args = args
+			err := options.Run()
+			CheckErr(err)
+		},
+	}
+	return cmd
+}
+
+// Run implements this command
+func (o *PluginListOptions) Run() error {
+	manager, err := o.pluginManager()
+	if err != nil {
+		return err
+	}
+
+	installed, err := manager.List()
+	if err != nil {
+		return err
+	}
+
+	table := o.CreateTable()
+	table.AddRow("NAME", "VERSION", "SOURCE", "LOCATION")
+	for _, p := range installed {
+		table.AddRow(p.Name, p.Version, "local", p.Path)
+	}
+
+	managed, err := o.managedPlugins()
+	if err == nil {
+		for _, p := range managed {
+			table.AddRow(p.Name, p.Version, "managed", "")
+		}
+	}
+
+	table.Render()
+	return nil
+}
+
+// managedPlugins lists the plugins registered via the cluster's Plugins CRD, if reachable
+func (o *PluginListOptions) managedPlugins() ([]plugin.Installed, error) {
+	jxClient, ns, err := o.JXClientAndDevNamespace()
+	if err != nil {
+		return nil, err
+	}
+
+	list, err := jxClient.JenkinsV1().Plugins(ns).List(metav1.ListOptions{})
+	if err != nil {
+		return nil, err
+	}
+
+	answer := []plugin.Installed{}
+	for _, p := range list.Items {
+		answer = append(answer, plugin.Installed{Name: p.Spec.Name, Version: p.Spec.Version})
+	}
+	return answer, nil
+}
+
+// PluginInstallOptions contains the command line options for `jx plugin install`
+type PluginInstallOptions struct {
+	PluginOptions
+}
+
+var (
+	pluginInstall_long = templates.LongDesc(i18n.T(`
+		Installs a plugin by name from the configured remote plugin index.
+
+		A specific version can be requested with name@version, otherwise the
+		version listed last for that name in the index is installed. Installing a plugin requires no
+		Kubernetes connection.`))
+
+	pluginInstall_example = templates.Examples(i18n.T(`
+		# Install the latest version of the foo plugin
+		jx plugin install foo
+
+		# Install a specific version of the foo plugin
+		jx plugin install foo@1.2.3`))
+)
+
+// NewCmdPluginInstall creates the `jx plugin install` command
+func NewCmdPluginInstall(commonOpts *CommonOptions) *cobra.Command {
+	options := &PluginInstallOptions{
+		PluginOptions: PluginOptions{CommonOptions: *commonOpts},
+	}
+
+	cmd := &cobra.Command{
+		Use:     "install <name>[@version]",
+		Short:   i18n.T("Installs a plugin"),
+		Long:    pluginInstall_long,
+		Example: pluginInstall_example,
+		Run: func(cmd *cobra.Command, args []string) {
+			options.Cmd = cmd
+			options.Args = args
+			err := options.Run()
+			CheckErr(err)
+		},
+	}
+	cmd.Flags().StringVarP(&options.IndexURL, "index-url", "", "", "Overrides the default remote plugin index URL")
+	return cmd
+}
+
+// Run implements this command
+func (o *PluginInstallOptions) Run() error {
+	if len(o.Args) == 0 {
+		return fmt.Errorf("must specify the name of the plugin to install")
+	}
+
+	manager, err := o.pluginManager()
+	if err != nil {
+		return err
+	}
+
+	for _, name := range o.Args {
+		path, err := manager.Install(name)
+		if err != nil {
+			return err
+		}
+		fmt.Fprintf(o.Out, "Installed %s at %s\n", name, path)
+	}
+	return nil
+}
+
+// PluginUninstallOptions contains the command line options for `jx plugin uninstall`
+type PluginUninstallOptions struct {
+	PluginOptions
+}
+
+// NewCmdPluginUninstall creates the `jx plugin uninstall` command
+func NewCmdPluginUninstall(commonOpts *CommonOptions) *cobra.Command {
+	options := &PluginUninstallOptions{
+		PluginOptions: PluginOptions{CommonOptions: *commonOpts},
+	}
+
+	cmd := &cobra.Command{
+		Use:   "uninstall <name>",
+		Short: i18n.T("Removes a locally installed plugin"),
+		Run: func(cmd *cobra.Command, args []string) {
+			options.Cmd = cmd
+			options.Args = args
+			err := options.Run()
+			CheckErr(err)
+		},
+	}
+	return cmd
+}
+
+// Run implements this command
+func (o *PluginUninstallOptions) Run() error {
+	if len(o.Args) == 0 {
+		return fmt.Errorf("must specify the name of the plugin to uninstall")
+	}
+
+	manager, err := o.pluginManager()
+	if err != nil {
+		return err
+	}
+
+	for _, name := range o.Args {
+		if err := manager.Uninstall(name); err != nil {
+			return err
+		}
+		fmt.Fprintf(o.Out, "Uninstalled %s\n", name)
+	}
+	return nil
+}
+
+// PluginDescribeOptions contains the command line options for `jx plugin describe`
+type PluginDescribeOptions struct {
+	PluginOptions
+}
+
+// NewCmdPluginDescribe creates the `jx plugin describe` command
+func NewCmdPluginDescribe(commonOpts *CommonOptions) *cobra.Command {
+	options := &PluginDescribeOptions{
+		PluginOptions: PluginOptions{CommonOptions: *commonOpts},
+	}
+
+	cmd := &cobra.Command{
+		Use:   "describe <name>",
+		Short: i18n.T("Describes the versions of a plugin available from the remote index"),
+		Run: func(cmd *cobra.Command, args []string) {
+			options.Cmd = cmd
+			options.Args = args
+			err := options.Run()
+			CheckErr(err)
+		},
+	}
+	cmd.Flags().StringVarP(&options.IndexURL, "index-url", "", "", "Overrides the default remote plugin index URL")
+	return cmd
+}
+
+// Run implements this command
+func (o *PluginDescribeOptions) Run() error {
+	if len(o.Args) == 0 {
+		return fmt.Errorf("must specify the name of the plugin to describe")
+	}
+
+	manager, err := o.pluginManager()
+	if err != nil {
+		return err
+	}
+
+	p, err := manager.Resolve(o.Args[0])
+	if err != nil {
+		return err
+	}
+
+	fmt.Fprintf(o.Out, "Name:    %s\n", p.Name)
+	fmt.Fprintf(o.Out, "Version: %s\n", p.Version)
+	for _, b := range p.Binaries {
+		fmt.Fprintf(o.Out, "  %s/%s -> %s\n", b.Goos, b.Goarch, b.URL)
+	}
+	return nil
+}
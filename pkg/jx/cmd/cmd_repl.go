@@ -0,0 +1,315 @@
+/*
+Copyright 2018 The Kubernetes Authors & The Jenkins X Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"syscall"
+
+	prompt "github.com/c-bata/go-prompt"
+	"github.com/jenkins-x/jx/pkg/jx/cmd/templates"
+	"github.com/jenkins-x/jx/pkg/jx/i18n"
+	"github.com/jenkins-x/jx/pkg/util"
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+)
+
+// ReplOptions contains the command line options for `jx repl`
+type ReplOptions struct {
+	CommonOptions
+
+	HistoryFile string
+	Root        *cobra.Command
+
+	namespace string
+	team      string
+	history   []string
+}
+
+var (
+	repl_long = templates.LongDesc(i18n.T(`
+		Starts an interactive jx shell.
+
+		Subsequent lines are executed as if they were arguments to jx, without
+		re-forking the binary for each command, so startup overhead is paid
+		once. Tab completes subcommand names and flags from the same command
+		tree "jx" itself uses, the Up/Down arrows recall previous lines from
+		~/.jx/history, "set namespace <ns>" and "set team <team>" mutate the
+		in-memory options used by later commands, and a line can be piped
+		through external commands, e.g. "get pipelines | grep foo".`))
+
+	repl_example = templates.Examples(i18n.T(`
+		# Start an interactive jx shell
+		jx repl`))
+)
+
+// NewCmdRepl creates the `jx repl` command
+func NewCmdRepl(commonOpts *CommonOptions) *cobra.Command {
+	options := &ReplOptions{
+		CommonOptions: *commonOpts,
+	}
+
+	cmd := &cobra.Command{
+		Use:     "repl",
+		Short:   i18n.T("Starts an interactive jx shell"),
+		Long:    repl_long,
+		Example: repl_example,
+		Run: func(cmd *cobra.Command, args []string) {
+			options.Cmd = cmd
+			options.Args = args
+			err := options.Run()
+			CheckErr(err)
+		},
+	}
+	return cmd
+}
+
+// Run implements this command
+func (o *ReplOptions) Run() error {
+	if o.HistoryFile == "" {
+		dir, err := util.ConfigDir()
+		if err != nil {
+			return err
+		}
+		o.HistoryFile = filepath.Join(dir, "history")
+	}
+
+	o.history = o.loadHistory()
+
+	fmt.Fprintln(o.Out, "Entering jx shell, type 'exit' or Ctrl-D to leave.")
+
+	p := prompt.New(
+		o.executor,
+		o.completer,
+		prompt.OptionPrefix("jx> "),
+		prompt.OptionHistory(o.history),
+	)
+	p.Run()
+
+	// only reached if go-prompt returns without the executor calling os.Exit,
+	// e.g. on Ctrl-D
+	return o.saveHistory(o.history)
+}
+
+// executor runs a single line entered at the prompt, recording it in history
+// before dispatching it, and persists history and exits on "exit"/"quit".
+func (o *ReplOptions) executor(line string) {
+	line = strings.TrimSpace(line)
+	if line == "" {
+		return
+	}
+	if line == "exit" || line == "quit" {
+		if err := o.saveHistory(o.history); err != nil {
+			fmt.Fprintf(o.Err, "%v\n", err)
+		}
+		os.Exit(0)
+	}
+
+	o.history = append(o.history, line)
+	o.execLine(line)
+}
+
+// completer offers the child commands and flags of whichever command the
+// text typed so far resolves to, reusing the same *cobra.Command tree "jx"
+// dispatches through so the suggestions never drift from what execLine accepts.
+func (o *ReplOptions) completer(d prompt.Document) []prompt.Suggest {
+	root := o.Root
+	if root == nil {
+		root = o.Cmd.Root()
+	}
+
+	text := d.TextBeforeCursor()
+	fields := strings.Fields(text)
+	trailingSpace := strings.HasSuffix(text, " ")
+
+	cmd := root
+	for i, field := range fields {
+		if i == len(fields)-1 && !trailingSpace {
+			// still typing this word: complete it rather than descending into it
+			break
+		}
+		next, _, err := cmd.Find([]string{field})
+		if err != nil || next == cmd {
+			break
+		}
+		cmd = next
+	}
+
+	suggestions := []prompt.Suggest{}
+	for _, c := range cmd.Commands() {
+		if c.Hidden {
+			continue
+		}
+		suggestions = append(suggestions, prompt.Suggest{Text: c.Name(), Description: c.Short})
+	}
+	cmd.Flags().VisitAll(func(f *pflag.Flag) {
+		suggestions = append(suggestions, prompt.Suggest{Text: "--" + f.Name, Description: f.Usage})
+	})
+
+	return prompt.FilterHasPrefix(suggestions, d.GetWordBeforeCursor(), true)
+}
+
+// execLine runs a single REPL line, handling the "set" pseudo-commands and
+// "jxArgs | shellCommand" piping before falling back to dispatching it
+// directly as jx subcommand arguments.
+func (o *ReplOptions) execLine(line string) {
+	jxPart := line
+	var pipeTo string
+	if idx := strings.Index(line, "|"); idx >= 0 {
+		jxPart = strings.TrimSpace(line[:idx])
+		pipeTo = strings.TrimSpace(line[idx+1:])
+	}
+
+	fields := strings.Fields(jxPart)
+	if len(fields) == 3 && fields[0] == "set" {
+		switch fields[1] {
+		case "namespace":
+			o.namespace = fields[2]
+			return
+		case "team":
+			o.team = fields[2]
+			return
+		}
+	}
+
+	if o.namespace != "" {
+		fields = append(fields, "--namespace", o.namespace)
+	}
+	if o.team != "" {
+		fields = append(fields, "--team", o.team)
+	}
+
+	root := o.Root
+	if root == nil {
+		root = o.Cmd.Root()
+	}
+
+	// pflag values set by a previous line (e.g. -o json) stick on the shared
+	// root command tree, so every flag must be put back to its default before
+	// the next Execute(), or a line that omits a flag silently inherits it.
+	resetFlags(root)
+
+	if pipeTo == "" {
+		root.SetArgs(fields)
+		if err := root.Execute(); err != nil {
+			fmt.Fprintf(o.Err, "%v\n", err)
+		}
+		return
+	}
+
+	root.SetArgs(fields)
+	var execErr error
+	out := captureRealStdout(func() {
+		execErr = root.Execute()
+	})
+	if execErr != nil {
+		fmt.Fprintf(o.Err, "%v\n", execErr)
+	}
+
+	shellCmd := exec.Command("sh", "-c", pipeTo)
+	shellCmd.Stdin = strings.NewReader(out)
+	shellCmd.Stdout = o.Out
+	shellCmd.Stderr = o.Err
+	if err := shellCmd.Run(); err != nil {
+		fmt.Fprintf(o.Err, "%v\n", err)
+	}
+}
+
+// captureRealStdout runs fn with the process's real stdout file descriptor
+// temporarily redirected to a pipe, returning whatever was written to it.
+// root.SetOutput only catches cobra's own Print*/usage helpers, and every
+// subcommand's options struct carries its own independent copy of the
+// os.Stdout captured once when the command tree was built, so neither
+// cmd.SetOutput nor reassigning the os.Stdout variable (as one copy of
+// many) is seen by what a subcommand actually writes through its Out.
+// Only redirecting the underlying fd is seen by all of them.
+func captureRealStdout(fn func()) string {
+	backupFd, err := syscall.Dup(int(os.Stdout.Fd()))
+	if err != nil {
+		fn()
+		return ""
+	}
+	backup := os.NewFile(uintptr(backupFd), "stdout-backup")
+	defer backup.Close()
+
+	r, w, err := os.Pipe()
+	if err != nil {
+		fn()
+		return ""
+	}
+
+	if err := syscall.Dup2(int(w.Fd()), int(os.Stdout.Fd())); err != nil {
+		w.Close()
+		r.Close()
+		fn()
+		return ""
+	}
+	w.Close()
+
+	var buf bytes.Buffer
+	done := make(chan struct{})
+	go func() { io.Copy(&buf, r); close(done) }()
+
+	fn()
+
+	syscall.Dup2(int(backup.Fd()), int(os.Stdout.Fd()))
+	<-done
+	r.Close()
+
+	return buf.String()
+}
+
+// resetFlags recursively restores cmd and its subcommands' flags to their
+// default values and clears Changed, undoing whatever the previous Execute() set.
+func resetFlags(cmd *cobra.Command) {
+	reset := func(f *pflag.Flag) {
+		if err := f.Value.Set(f.DefValue); err == nil {
+			f.Changed = false
+		}
+	}
+	cmd.Flags().VisitAll(reset)
+	cmd.PersistentFlags().VisitAll(reset)
+	for _, c := range cmd.Commands() {
+		resetFlags(c)
+	}
+}
+
+func (o *ReplOptions) loadHistory() []string {
+	data, err := ioutil.ReadFile(o.HistoryFile)
+	if err != nil {
+		return []string{}
+	}
+	lines := strings.Split(strings.TrimSpace(string(data)), "\n")
+	if len(lines) == 1 && lines[0] == "" {
+		return []string{}
+	}
+	return lines
+}
+
+func (o *ReplOptions) saveHistory(history []string) error {
+	if err := os.MkdirAll(filepath.Dir(o.HistoryFile), 0755); err != nil {
+		return err
+	}
+	return ioutil.WriteFile(o.HistoryFile, []byte(strings.Join(history, "\n")+"\n"), 0644)
+}
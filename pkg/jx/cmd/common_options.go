@@ -0,0 +1,133 @@
+/*
+Copyright 2018 The Kubernetes Authors & The Jenkins X Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/jenkins-x/jx/pkg/jx/cmd/clients"
+	"github.com/spf13/cobra"
+	"gopkg.in/AlecAivazis/survey.v1/terminal"
+)
+
+// CommonOptions holds the global flags and shared state passed down to
+// every subcommand built by NewJXCommand.
+type CommonOptions struct {
+	OutputOption
+	ReportOption
+
+	factory clients.Factory
+	In      terminal.FileReader
+	Out     terminal.FileWriter
+	Err     io.Writer
+
+	Cmd  *cobra.Command
+	Args []string
+}
+
+// Factory returns the clients.Factory used to create Kubernetes/Jenkins X clients
+func (o *CommonOptions) Factory() clients.Factory {
+	return o.factory
+}
+
+// addCommonFlags registers the -o/--format/--columns flags from OutputOption and
+// the --report flag from ReportOption as local flags of cmd. They are not
+// persistent flags, so subcommands do not inherit them; each subcommand that
+// supports them registers its own via addOutputOptions/addReportFlags.
+func (o *CommonOptions) addCommonFlags(cmd *cobra.Command) {
+	o.addOutputOptions(cmd)
+	o.addReportFlags(cmd)
+}
+
+// CreateTable returns a Table that writes to o.Out, respecting --columns if it was set
+func (o *CommonOptions) CreateTable() Table {
+	return o.OutputOption.CreateTable(o.Out)
+}
+
+// RenderList renders a "get"-style list: items is marshalled via Output() for
+// json/yaml/jsonpath formats, or rendered as a table with the given header
+// and one row per index in [0, rows) built by rowFn, for the default "table" format.
+func (o *CommonOptions) RenderList(items interface{}, header []string, rows int, rowFn func(i int) []string) error {
+	if !o.IsTableFormat() {
+		data, err := o.Output(items)
+		if err != nil {
+			return err
+		}
+		fmt.Fprintln(o.Out, string(data))
+		return nil
+	}
+
+	table := o.CreateTable()
+	table.AddRow(header...)
+	for i := 0; i < rows; i++ {
+		table.AddRow(rowFn(i)...)
+	}
+	table.Render()
+	return nil
+}
+
+// CheckErr prints err (if non-nil) and exits with a non-zero status, mirroring
+// cobra/kubectl's convention for command Run functions that can't return an error
+func CheckErr(err error) {
+	if err == nil {
+		return
+	}
+	fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+	os.Exit(1)
+}
+
+// CaptureOutput temporarily redirects Out and Err to pipes for the duration
+// of fn, restoring the originals before returning, and yields whatever fn
+// wrote to each as stdout/stderr. Commands write through Out/Err rather than
+// the process's real os.Stdout/os.Stderr, so this is the only way to capture
+// what a command actually prints without changing how it prints it.
+func (o *CommonOptions) CaptureOutput(fn func() error) (stdout string, stderr string, err error) {
+	outR, outW, pipeErr := os.Pipe()
+	if pipeErr != nil {
+		return "", "", fn()
+	}
+	errR, errW, pipeErr := os.Pipe()
+	if pipeErr != nil {
+		outR.Close()
+		outW.Close()
+		return "", "", fn()
+	}
+
+	origOut, origErr := o.Out, o.Err
+	o.Out, o.Err = outW, errW
+
+	var outBuf, errBuf bytes.Buffer
+	outDone := make(chan struct{})
+	errDone := make(chan struct{})
+	go func() { io.Copy(&outBuf, outR); close(outDone) }()
+	go func() { io.Copy(&errBuf, errR); close(errDone) }()
+
+	err = fn()
+
+	o.Out, o.Err = origOut, origErr
+	outW.Close()
+	errW.Close()
+	<-outDone
+	<-errDone
+	outR.Close()
+	errR.Close()
+
+	return outBuf.String(), errBuf.String(), err
+}
@@ -0,0 +1,49 @@
+/*
+Copyright 2018 The Kubernetes Authors & The Jenkins X Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"github.com/jenkins-x/jx/pkg/jx/cmd/templates"
+	"github.com/jenkins-x/jx/pkg/jx/i18n"
+	"github.com/spf13/cobra"
+)
+
+var get_long = templates.LongDesc(i18n.T(`
+	Valid resource types include:
+
+    * environments (aka 'env')
+    * pipelines (aka 'pipe')
+    * urls (aka 'url')
+    * activities (aka 'act')`))
+
+// NewCmdGet creates the `jx get` command and its resource-specific children.
+// Every child inherits -o/--format/--columns from OutputOption via CommonOptions.
+func NewCmdGet(commonOpts *CommonOptions) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:     "get TYPE [flags]",
+		Short:   i18n.T("Displays one or more Jenkins X resources"),
+		Long:    get_long,
+		Aliases: []string{"list"},
+		Run:     runHelp,
+	}
+
+	cmd.AddCommand(NewCmdGetEnvironments(commonOpts))
+	cmd.AddCommand(NewCmdGetPipelines(commonOpts))
+	cmd.AddCommand(NewCmdGetURLs(commonOpts))
+	cmd.AddCommand(NewCmdGetActivities(commonOpts))
+	return cmd
+}
@@ -0,0 +1,100 @@
+/*
+Copyright 2018 The Kubernetes Authors & The Jenkins X Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"strings"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/jenkins-x/jx/pkg/jx/cmd/templates"
+	"github.com/jenkins-x/jx/pkg/jx/i18n"
+	"github.com/spf13/cobra"
+)
+
+// GetActivitiesOptions contains the command line options for `jx get activities`
+type GetActivitiesOptions struct {
+	CommonOptions
+
+	Filter string
+}
+
+var (
+	getActivities_long = templates.LongDesc(i18n.T(`
+		Displays the pipeline activities for the current team.`))
+
+	getActivities_example = templates.Examples(i18n.T(`
+		# List all pipeline activities
+		jx get activities
+
+		# List the activities for a single pipeline as YAML
+		jx get activities --filter my-org/my-app -o yaml`))
+)
+
+// NewCmdGetActivities creates the `jx get activities` command
+func NewCmdGetActivities(commonOpts *CommonOptions) *cobra.Command {
+	options := &GetActivitiesOptions{
+		CommonOptions: *commonOpts,
+	}
+
+	cmd := &cobra.Command{
+		Use:     "activities",
+		Short:   i18n.T("Lists the pipeline activities for the current team"),
+		Long:    getActivities_long,
+		Example: getActivities_example,
+		Aliases: []string{"activity", "act"},
+		Run: func(cmd *cobra.Command, args []string) {
+			options.Cmd = cmd
+			options.Args = args
+			err := options.Run()
+			CheckErr(err)
+		},
+	}
+	cmd.Flags().StringVarP(&options.Filter, "filter", "", "", "Only show activities for pipelines whose name contains this text")
+	options.addOutputOptions(cmd)
+	return cmd
+}
+
+// Run implements this command
+func (o *GetActivitiesOptions) Run() error {
+	jxClient, ns, err := o.JXClientAndDevNamespace()
+	if err != nil {
+		return err
+	}
+
+	list, err := jxClient.JenkinsV1().PipelineActivities(ns).List(metav1.ListOptions{})
+	if err != nil {
+		return err
+	}
+
+	items := list.Items
+	if o.Filter != "" {
+		filtered := items[:0]
+		for _, a := range items {
+			if strings.Contains(a.Spec.Pipeline, o.Filter) {
+				filtered = append(filtered, a)
+			}
+		}
+		items = filtered
+	}
+
+	header := []string{"NAME", "PIPELINE", "BUILD", "STATUS"}
+	return o.RenderList(items, header, len(items), func(i int) []string {
+		a := items[i]
+		return []string{a.Name, a.Spec.Pipeline, a.Spec.Build, string(a.Spec.Status)}
+	})
+}
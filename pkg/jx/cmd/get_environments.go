@@ -0,0 +1,84 @@
+/*
+Copyright 2018 The Kubernetes Authors & The Jenkins X Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/jenkins-x/jx/pkg/jx/cmd/templates"
+	"github.com/jenkins-x/jx/pkg/jx/i18n"
+	"github.com/spf13/cobra"
+)
+
+// GetEnvironmentsOptions contains the command line options for `jx get environments`
+type GetEnvironmentsOptions struct {
+	CommonOptions
+}
+
+var (
+	getEnvironments_long = templates.LongDesc(i18n.T(`
+		Displays the environments defined for the current team.`))
+
+	getEnvironments_example = templates.Examples(i18n.T(`
+		# List the environments
+		jx get environments
+
+		# List the environments as JSON
+		jx get environments -o json`))
+)
+
+// NewCmdGetEnvironments creates the `jx get environments` command
+func NewCmdGetEnvironments(commonOpts *CommonOptions) *cobra.Command {
+	options := &GetEnvironmentsOptions{
+		CommonOptions: *commonOpts,
+	}
+
+	cmd := &cobra.Command{
+		Use:     "environments",
+		Short:   i18n.T("Lists the environments in the current team"),
+		Long:    getEnvironments_long,
+		Example: getEnvironments_example,
+		Aliases: []string{"environment", "env"},
+		Run: func(cmd *cobra.Command, args []string) {
+			options.Cmd = cmd
+			options.Args = args
+			err := options.Run()
+			CheckErr(err)
+		},
+	}
+	options.addOutputOptions(cmd)
+	return cmd
+}
+
+// Run implements this command
+func (o *GetEnvironmentsOptions) Run() error {
+	jxClient, ns, err := o.JXClientAndDevNamespace()
+	if err != nil {
+		return err
+	}
+
+	list, err := jxClient.JenkinsV1().Environments(ns).List(metav1.ListOptions{})
+	if err != nil {
+		return err
+	}
+
+	header := []string{"NAME", "NAMESPACE", "KIND", "PROMOTION"}
+	return o.RenderList(list.Items, header, len(list.Items), func(i int) []string {
+		env := list.Items[i]
+		return []string{env.Name, env.Spec.Namespace, string(env.Spec.Kind), string(env.Spec.PromotionStrategy)}
+	})
+}
@@ -0,0 +1,107 @@
+/*
+Copyright 2018 The Kubernetes Authors & The Jenkins X Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/jenkins-x/jx/pkg/jx/cmd/templates"
+	"github.com/jenkins-x/jx/pkg/jx/i18n"
+	"github.com/spf13/cobra"
+)
+
+// pipelineSummary is the distinct set of pipelines derived from the most
+// recent activity for each one, used to render `jx get pipelines`.
+type pipelineSummary struct {
+	Name      string `json:"name"`
+	LastBuild string `json:"lastBuild"`
+	Status    string `json:"status"`
+}
+
+// GetPipelinesOptions contains the command line options for `jx get pipelines`
+type GetPipelinesOptions struct {
+	CommonOptions
+}
+
+var (
+	getPipelines_long = templates.LongDesc(i18n.T(`
+		Displays the current pipelines and their most recent build status.`))
+
+	getPipelines_example = templates.Examples(i18n.T(`
+		# List the pipelines
+		jx get pipelines`))
+)
+
+// NewCmdGetPipelines creates the `jx get pipelines` command
+func NewCmdGetPipelines(commonOpts *CommonOptions) *cobra.Command {
+	options := &GetPipelinesOptions{
+		CommonOptions: *commonOpts,
+	}
+
+	cmd := &cobra.Command{
+		Use:     "pipelines",
+		Short:   i18n.T("Lists the current pipelines"),
+		Long:    getPipelines_long,
+		Example: getPipelines_example,
+		Aliases: []string{"pipe", "pipeline"},
+		Run: func(cmd *cobra.Command, args []string) {
+			options.Cmd = cmd
+			options.Args = args
+			err := options.Run()
+			CheckErr(err)
+		},
+	}
+	options.addOutputOptions(cmd)
+	return cmd
+}
+
+// Run implements this command
+func (o *GetPipelinesOptions) Run() error {
+	jxClient, ns, err := o.JXClientAndDevNamespace()
+	if err != nil {
+		return err
+	}
+
+	list, err := jxClient.JenkinsV1().PipelineActivities(ns).List(metav1.ListOptions{})
+	if err != nil {
+		return err
+	}
+
+	latestCreated := map[string]metav1.Time{}
+	latest := map[string]pipelineSummary{}
+	for _, a := range list.Items {
+		if existing, ok := latestCreated[a.Spec.Pipeline]; ok && !a.CreationTimestamp.After(existing.Time) {
+			continue
+		}
+		latestCreated[a.Spec.Pipeline] = a.CreationTimestamp
+		latest[a.Spec.Pipeline] = pipelineSummary{
+			Name:      a.Spec.Pipeline,
+			LastBuild: a.Spec.Build,
+			Status:    string(a.Spec.Status),
+		}
+	}
+	summaries := make([]pipelineSummary, 0, len(latest))
+	for _, s := range latest {
+		summaries = append(summaries, s)
+	}
+
+	header := []string{"NAME", "LAST BUILD", "STATUS"}
+	return o.RenderList(summaries, header, len(summaries), func(i int) []string {
+		s := summaries[i]
+		return []string{s.Name, s.LastBuild, s.Status}
+	})
+}
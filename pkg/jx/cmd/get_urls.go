@@ -0,0 +1,97 @@
+/*
+Copyright 2018 The Kubernetes Authors & The Jenkins X Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/jenkins-x/jx/pkg/jx/cmd/templates"
+	"github.com/jenkins-x/jx/pkg/jx/i18n"
+	"github.com/jenkins-x/jx/pkg/kube"
+	"github.com/spf13/cobra"
+)
+
+// serviceURL is a single exposed service, used to render `jx get urls`.
+type serviceURL struct {
+	Name string `json:"name"`
+	URL  string `json:"url"`
+}
+
+// GetURLsOptions contains the command line options for `jx get urls`
+type GetURLsOptions struct {
+	CommonOptions
+}
+
+var (
+	getURLs_long = templates.LongDesc(i18n.T(`
+		Displays the URLs of exposed services in the current namespace.`))
+
+	getURLs_example = templates.Examples(i18n.T(`
+		# List the exposed service URLs
+		jx get urls`))
+)
+
+// NewCmdGetURLs creates the `jx get urls` command
+func NewCmdGetURLs(commonOpts *CommonOptions) *cobra.Command {
+	options := &GetURLsOptions{
+		CommonOptions: *commonOpts,
+	}
+
+	cmd := &cobra.Command{
+		Use:     "urls",
+		Short:   i18n.T("Lists the URLs of exposed services"),
+		Long:    getURLs_long,
+		Example: getURLs_example,
+		Aliases: []string{"url"},
+		Run: func(cmd *cobra.Command, args []string) {
+			options.Cmd = cmd
+			options.Args = args
+			err := options.Run()
+			CheckErr(err)
+		},
+	}
+	options.addOutputOptions(cmd)
+	return cmd
+}
+
+// Run implements this command
+func (o *GetURLsOptions) Run() error {
+	kubeClient, ns, err := o.KubeClientAndNamespace()
+	if err != nil {
+		return err
+	}
+
+	services, err := kubeClient.CoreV1().Services(ns).List(metav1.ListOptions{})
+	if err != nil {
+		return err
+	}
+
+	urls := []serviceURL{}
+	for _, svc := range services.Items {
+		url := kube.GetServiceURL(&svc)
+		if url == "" {
+			continue
+		}
+		urls = append(urls, serviceURL{Name: svc.Name, URL: url})
+	}
+
+	header := []string{"NAME", "URL"}
+	return o.RenderList(urls, header, len(urls), func(i int) []string {
+		u := urls[i]
+		return []string{u.Name, u.URL}
+	})
+}
@@ -0,0 +1,193 @@
+/*
+Copyright 2018 The Kubernetes Authors & The Jenkins X Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/ghodss/yaml"
+	"github.com/spf13/cobra"
+	"k8s.io/client-go/util/jsonpath"
+)
+
+const (
+	defaultOutputFormat = "table"
+)
+
+// FormatOutput is implemented by custom resources that want to control how
+// they are rendered by OutputOption rather than relying on the default
+// JSON/YAML/jsonpath marshalling.
+type FormatOutput interface {
+	// FormatOutput renders obj for the given format ("table", "json", "yaml" or "jsonpath=...").
+	// It returns ok=false if it does not have a custom renderer for format,
+	// in which case OutputOption falls back to its default behaviour.
+	FormatOutput(format string, out io.Writer) (ok bool, err error)
+}
+
+// OutputOption is embedded by CommonOptions and provides the shared -o/--format flag
+type OutputOption struct {
+	OutputFormat string
+	Columns      string
+}
+
+// addOutputOptions registers the -o/--format and --columns flags on cmd
+func (o *OutputOption) addOutputOptions(cmd *cobra.Command) {
+	cmd.Flags().StringVarP(&o.OutputFormat, "format", "o", "", "The output format such as 'table', 'json', 'yaml' or 'jsonpath=...'. Defaults to 'table'")
+	cmd.Flags().StringVarP(&o.Columns, "columns", "", "", "A comma separated list of column names to restrict table output to, e.g. 'name,status'")
+}
+
+// Output renders obj using the configured format, defaulting to "table" if none was specified.
+// Callers that support a native table representation should check o.OutputFormat == "" or "table"
+// first and render their own table; Output() is typically used for the json/yaml/jsonpath paths.
+func (o *OutputOption) Output(obj interface{}) ([]byte, error) {
+	format := o.OutputFormat
+	if format == "" {
+		format = defaultOutputFormat
+	}
+
+	if custom, ok := obj.(FormatOutput); ok {
+		buf := &strings.Builder{}
+		handled, err := custom.FormatOutput(format, buf)
+		if err != nil {
+			return nil, err
+		}
+		if handled {
+			return []byte(buf.String()), nil
+		}
+	}
+
+	switch {
+	case format == "json":
+		data, err := json.MarshalIndent(obj, "", "  ")
+		if err != nil {
+			return nil, fmt.Errorf("failed to marshal to json: %v", err)
+		}
+		return data, nil
+	case format == "yaml":
+		data, err := yaml.Marshal(obj)
+		if err != nil {
+			return nil, fmt.Errorf("failed to marshal to yaml: %v", err)
+		}
+		return data, nil
+	case strings.HasPrefix(format, "jsonpath="):
+		return outputJSONPath(obj, strings.TrimPrefix(format, "jsonpath="))
+	case format == "table" || format == "":
+		return nil, fmt.Errorf("table output must be rendered by the command, not Output()")
+	default:
+		return nil, fmt.Errorf("unsupported output format: %s", format)
+	}
+}
+
+// IsTableFormat returns true if no explicit machine-readable format was requested
+func (o *OutputOption) IsTableFormat() bool {
+	return o.OutputFormat == "" || o.OutputFormat == defaultOutputFormat
+}
+
+// Table is a minimal tab-aligned table renderer that honours --columns,
+// returned by OutputOption.CreateTable for "get"/"describe" style commands.
+type Table struct {
+	out     io.Writer
+	filter  map[string]bool
+	header  []string
+	indexes []int
+	writer  *tabwriter.Writer
+}
+
+// CreateTable returns a Table that writes to out, restricted to the columns
+// named in --columns if it was set.
+func (o *OutputOption) CreateTable(out io.Writer) Table {
+	var filter map[string]bool
+	if o.Columns != "" {
+		filter = map[string]bool{}
+		for _, name := range strings.Split(o.Columns, ",") {
+			filter[strings.ToUpper(strings.TrimSpace(name))] = true
+		}
+	}
+	return Table{
+		out:    out,
+		filter: filter,
+		writer: tabwriter.NewWriter(out, 0, 0, 2, ' ', 0),
+	}
+}
+
+// AddRow writes a row of cells. The first call establishes the header and,
+// when --columns was set, which column indexes are kept for every later row.
+func (t *Table) AddRow(cells ...string) {
+	if t.header == nil {
+		t.header = cells
+		t.indexes = t.selectColumns(cells)
+	}
+	fmt.Fprintln(t.writer, strings.Join(t.selectedCells(cells), "\t"))
+}
+
+// Render flushes the buffered rows to the underlying writer.
+func (t *Table) Render() {
+	t.writer.Flush()
+}
+
+func (t *Table) selectColumns(header []string) []int {
+	if t.filter == nil {
+		indexes := make([]int, len(header))
+		for i := range header {
+			indexes[i] = i
+		}
+		return indexes
+	}
+	indexes := []int{}
+	for i, name := range header {
+		if t.filter[strings.ToUpper(name)] {
+			indexes = append(indexes, i)
+		}
+	}
+	return indexes
+}
+
+func (t *Table) selectedCells(cells []string) []string {
+	answer := make([]string, 0, len(t.indexes))
+	for _, i := range t.indexes {
+		if i < len(cells) {
+			answer = append(answer, cells[i])
+		}
+	}
+	return answer
+}
+
+func outputJSONPath(obj interface{}, template string) ([]byte, error) {
+	data, err := json.Marshal(obj)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal object for jsonpath: %v", err)
+	}
+	var generic interface{}
+	if err := json.Unmarshal(data, &generic); err != nil {
+		return nil, err
+	}
+
+	jp := jsonpath.New("output")
+	if err := jp.Parse(fmt.Sprintf("{%s}", template)); err != nil {
+		return nil, fmt.Errorf("invalid jsonpath %q: %v", template, err)
+	}
+
+	buf := &strings.Builder{}
+	if err := jp.Execute(buf, generic); err != nil {
+		return nil, fmt.Errorf("failed to execute jsonpath %q: %v", template, err)
+	}
+	return []byte(buf.String()), nil
+}
@@ -0,0 +1,97 @@
+/*
+Copyright 2018 The Kubernetes Authors & The Jenkins X Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package plugin
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
+	"gopkg.in/yaml.v2"
+)
+
+// LayoutPluginKeyField is the field name persisted on the project's
+// jx-requirements config to record which provider (from --plugins, e.g.
+// "managed" or "managed:v2") scaffolded the project. Commands that
+// generate project layouts should set requirements.Layout.PluginKey to the
+// Provider.Name (plus ":"+Version when set) they resolved, so later `jx`
+// invocations in that project can default --plugins to the same provider.
+const LayoutPluginKeyField = "pluginKey"
+
+const requirementsFileName = "jx-requirements.yml"
+
+// Key renders the provider as the "name" or "name:version" string stored
+// under LayoutPluginKeyField.
+func (p Provider) Key() string {
+	if p.Version == "" {
+		return p.Name
+	}
+	return p.Name + ":" + p.Version
+}
+
+// Layout is the subset of a project's jx-requirements.yml this package
+// reads and writes; unknown fields in the file are preserved as Extra.
+type Layout struct {
+	PluginKey string                 `yaml:"pluginKey,omitempty"`
+	Extra     map[string]interface{} `yaml:",inline"`
+}
+
+// requirements mirrors the top-level jx-requirements.yml shape far enough
+// to round-trip Layout without disturbing whatever else is in the file.
+type requirements struct {
+	Layout Layout                 `yaml:"layout"`
+	Extra  map[string]interface{} `yaml:",inline"`
+}
+
+// LoadLayoutKey returns the LayoutPluginKeyField last persisted by
+// SaveLayoutKey for the project rooted at dir, or "" if dir has no
+// jx-requirements.yml or it names no plugin key.
+func LoadLayoutKey(dir string) string {
+	data, err := ioutil.ReadFile(filepath.Join(dir, requirementsFileName))
+	if err != nil {
+		return ""
+	}
+	reqs := requirements{}
+	if err := yaml.Unmarshal(data, &reqs); err != nil {
+		return ""
+	}
+	return reqs.Layout.PluginKey
+}
+
+// SaveLayoutKey persists key (see Provider.Key) as the LayoutPluginKeyField
+// of the project's jx-requirements.yml in dir, creating the file if needed
+// and preserving any other fields already present in it.
+func SaveLayoutKey(dir string, key string) error {
+	path := filepath.Join(dir, requirementsFileName)
+
+	reqs := requirements{}
+	if data, err := ioutil.ReadFile(path); err == nil {
+		if err := yaml.Unmarshal(data, &reqs); err != nil {
+			return err
+		}
+	} else if !os.IsNotExist(err) {
+		return err
+	}
+
+	reqs.Layout.PluginKey = key
+
+	data, err := yaml.Marshal(&reqs)
+	if err != nil {
+		return err
+	}
+	return ioutil.WriteFile(path, data, 0644)
+}
@@ -0,0 +1,346 @@
+/*
+Copyright 2018 The Kubernetes Authors & The Jenkins X Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package plugin provides a Manager that resolves, downloads and caches
+// binary jx plugins independently of any Kubernetes connection.
+package plugin
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"syscall"
+
+	"github.com/jenkins-x/jx/pkg/log"
+	"gopkg.in/yaml.v2"
+)
+
+const (
+	// DefaultIndexURL is used when no remote index has been configured.
+	DefaultIndexURL = "https://raw.githubusercontent.com/jenkins-x/jx-plugins/master/plugins.yml"
+
+	pluginsDirName = "plugins"
+)
+
+// Binary describes a single OS/arch download for a plugin version.
+type Binary struct {
+	Goos   string `json:"goos" yaml:"goos"`
+	Goarch string `json:"goarch" yaml:"goarch"`
+	URL    string `json:"url" yaml:"url"`
+	Sha256 string `json:"sha256" yaml:"sha256"`
+}
+
+// Plugin describes a single version of a named plugin in the remote index.
+type Plugin struct {
+	Name     string   `json:"name" yaml:"name"`
+	Version  string   `json:"version" yaml:"version"`
+	Binaries []Binary `json:"binaries" yaml:"binaries"`
+}
+
+// Index is the remote catalog of installable plugins.
+type Index struct {
+	Plugins []Plugin `json:"plugins" yaml:"plugins"`
+}
+
+// Installed describes a plugin already present in the local cache.
+type Installed struct {
+	Name    string
+	Version string
+	Path    string
+}
+
+// Manager resolves plugins by name from a remote index, downloads and
+// caches them under <home>/plugins/<name>/<version>/ and verifies their
+// checksum before marking them executable.
+type Manager struct {
+	HomeDir    string
+	IndexURL   string
+	HTTPClient *http.Client
+}
+
+// NewManager creates a Manager which caches plugins under homeDir and
+// resolves them against the given remote index URL.
+func NewManager(homeDir string, indexURL string) *Manager {
+	if indexURL == "" {
+		indexURL = DefaultIndexURL
+	}
+	return &Manager{
+		HomeDir:    homeDir,
+		IndexURL:   indexURL,
+		HTTPClient: http.DefaultClient,
+	}
+}
+
+// LoadIndex downloads and parses the remote plugin index. It accepts
+// either YAML or JSON as YAML is a superset of JSON.
+func (m *Manager) LoadIndex() (*Index, error) {
+	resp, err := m.HTTPClient.Get(m.IndexURL)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch plugin index %s: %v", m.IndexURL, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("failed to fetch plugin index %s: status %s", m.IndexURL, resp.Status)
+	}
+
+	data, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read plugin index %s: %v", m.IndexURL, err)
+	}
+
+	index := &Index{}
+	if err := yaml.Unmarshal(data, index); err != nil {
+		return nil, fmt.Errorf("failed to parse plugin index %s: %v", m.IndexURL, err)
+	}
+	return index, nil
+}
+
+// Resolve finds the named plugin in the remote index. name may be a bare
+// plugin name, in which case the matching entry listed last in the index
+// wins (version strings are not compared), or "name@version" to pin an
+// exact version.
+func (m *Manager) Resolve(name string) (*Plugin, error) {
+	pluginName, version := splitNameVersion(name)
+
+	index, err := m.LoadIndex()
+	if err != nil {
+		return nil, err
+	}
+
+	var found *Plugin
+	for i := range index.Plugins {
+		p := &index.Plugins[i]
+		if p.Name != pluginName {
+			continue
+		}
+		if version != "" && p.Version != version {
+			continue
+		}
+		found = p
+	}
+	if found == nil {
+		if version != "" {
+			return nil, fmt.Errorf("no plugin found for %s@%s in %s", pluginName, version, m.IndexURL)
+		}
+		return nil, fmt.Errorf("no plugin found for %s in %s", pluginName, m.IndexURL)
+	}
+	return found, nil
+}
+
+// Install resolves, downloads, verifies and caches the named plugin,
+// returning the path to the executable binary.
+func (m *Manager) Install(name string) (string, error) {
+	p, err := m.Resolve(name)
+	if err != nil {
+		return "", err
+	}
+
+	binary, err := binaryForPlatform(p, runtime.GOOS, runtime.GOARCH)
+	if err != nil {
+		return "", err
+	}
+
+	if err := validatePathComponent(p.Name); err != nil {
+		return "", fmt.Errorf("plugin index entry has an invalid name: %v", err)
+	}
+	if err := validatePathComponent(p.Version); err != nil {
+		return "", fmt.Errorf("plugin index entry for %s has an invalid version: %v", p.Name, err)
+	}
+
+	dir := m.versionDir(p.Name, p.Version)
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return "", fmt.Errorf("failed to create plugin directory %s: %v", dir, err)
+	}
+
+	path := filepath.Join(dir, binaryName(p.Name))
+	if err := downloadFile(m.HTTPClient, binary.URL, path); err != nil {
+		return "", err
+	}
+
+	if err := verifyChecksum(path, binary.Sha256); err != nil {
+		os.Remove(path)
+		return "", err
+	}
+
+	if err := os.Chmod(path, 0755); err != nil {
+		return "", fmt.Errorf("failed to mark plugin %s executable: %v", path, err)
+	}
+
+	log.Infof("Installed plugin %s version %s to %s\n", p.Name, p.Version, path)
+	return path, nil
+}
+
+// Uninstall removes every cached version of the named plugin.
+func (m *Manager) Uninstall(name string) error {
+	pluginName, _ := splitNameVersion(name)
+	dir := filepath.Join(m.HomeDir, pluginsDirName, pluginName)
+	if _, err := os.Stat(dir); os.IsNotExist(err) {
+		return fmt.Errorf("plugin %s is not installed", pluginName)
+	}
+	return os.RemoveAll(dir)
+}
+
+// List returns every plugin version currently cached locally.
+func (m *Manager) List() ([]Installed, error) {
+	root := filepath.Join(m.HomeDir, pluginsDirName)
+	answer := []Installed{}
+
+	names, err := ioutil.ReadDir(root)
+	if os.IsNotExist(err) {
+		return answer, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	for _, name := range names {
+		if !name.IsDir() {
+			continue
+		}
+		versions, err := ioutil.ReadDir(filepath.Join(root, name.Name()))
+		if err != nil {
+			continue
+		}
+		for _, version := range versions {
+			if !version.IsDir() {
+				continue
+			}
+			answer = append(answer, Installed{
+				Name:    name.Name(),
+				Version: version.Name(),
+				Path:    filepath.Join(root, name.Name(), version.Name(), binaryName(name.Name())),
+			})
+		}
+	}
+	return answer, nil
+}
+
+// Lookup implements the PluginHandler interface, returning the cached
+// executable path for filename if one has already been installed.
+// filename may be the plugin name itself or the "jx-<name>" form that
+// handleEndpointExtensions looks up.
+func (m *Manager) Lookup(filename string) (string, error) {
+	installed, err := m.List()
+	if err != nil {
+		return "", err
+	}
+	for _, p := range installed {
+		if binaryName(p.Name) == filename || "jx-"+p.Name == filename {
+			return p.Path, nil
+		}
+	}
+	return "", fmt.Errorf("no cached plugin found for %s", filename)
+}
+
+// Execute implements the PluginHandler interface, exec'ing the cached binary
+// in place of the current process the same way localPluginHandler does.
+func (m *Manager) Execute(executablePath string, cmdArgs, environment []string) error {
+	return syscall.Exec(executablePath, cmdArgs, environment)
+}
+
+func (m *Manager) versionDir(name string, version string) string {
+	return filepath.Join(m.HomeDir, pluginsDirName, name, version)
+}
+
+func binaryName(name string) string {
+	if runtime.GOOS == "windows" {
+		return name + ".exe"
+	}
+	return name
+}
+
+func binaryForPlatform(p *Plugin, goos string, goarch string) (*Binary, error) {
+	for _, b := range p.Binaries {
+		if b.Goos == goos && b.Goarch == goarch {
+			return &b, nil
+		}
+	}
+	return nil, fmt.Errorf("plugin %s version %s has no binary for %s/%s", p.Name, p.Version, goos, goarch)
+}
+
+// validatePathComponent rejects values that aren't safe to use as a single
+// path segment under HomeDir, guarding against a malicious or compromised
+// --index-url response steering Install's filepath.Join outside it (e.g. a
+// version of "../../../../.ssh").
+func validatePathComponent(s string) error {
+	if s == "" || s == "." || s == ".." || strings.ContainsAny(s, `/\`) {
+		return fmt.Errorf("%q is not a valid path component", s)
+	}
+	return nil
+}
+
+func splitNameVersion(name string) (string, string) {
+	for i := 0; i < len(name); i++ {
+		if name[i] == '@' {
+			return name[:i], name[i+1:]
+		}
+	}
+	return name, ""
+}
+
+func downloadFile(client *http.Client, url string, path string) error {
+	resp, err := client.Get(url)
+	if err != nil {
+		return fmt.Errorf("failed to download %s: %v", url, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("failed to download %s: status %s", url, resp.Status)
+	}
+
+	out, err := os.Create(path)
+	if err != nil {
+		return fmt.Errorf("failed to create %s: %v", path, err)
+	}
+	defer out.Close()
+
+	if _, err := io.Copy(out, resp.Body); err != nil {
+		return fmt.Errorf("failed to write %s: %v", path, err)
+	}
+	return nil
+}
+
+func verifyChecksum(path string, expected string) error {
+	if expected == "" {
+		return fmt.Errorf("no checksum published for %s, refusing to install", path)
+	}
+
+	f, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	h := sha256.New()
+	if _, err := io.Copy(h, f); err != nil {
+		return err
+	}
+
+	actual := hex.EncodeToString(h.Sum(nil))
+	if actual != expected {
+		return fmt.Errorf("checksum mismatch for %s: expected %s but got %s", path, expected, actual)
+	}
+	return nil
+}
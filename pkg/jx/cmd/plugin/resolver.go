@@ -0,0 +1,161 @@
+/*
+Copyright 2018 The Kubernetes Authors & The Jenkins X Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package plugin
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Provider is a single entry parsed from the --plugins flag, e.g. "managed"
+// or "managed:v2". Version is empty when the provider name is unversioned.
+type Provider struct {
+	Name    string
+	Version string
+}
+
+// ProviderHandler looks up a plugin executable for a single provider, e.g.
+// the cluster-managed Plugins CRD or the local $PATH.
+type ProviderHandler interface {
+	Lookup(filename string) (string, error)
+}
+
+// Resolver consults an ordered list of named ProviderHandlers to find a
+// plugin executable, following the order requested via --plugins (or the
+// factory's default order when the flag is absent).
+type Resolver struct {
+	providers    []Provider
+	handlers     map[string]ProviderHandler
+	lastProvider Provider
+}
+
+// Option configures a Resolver at construction time.
+type Option func(*Resolver)
+
+// WithDefaultPlugins sets the provider order a Resolver falls back to when
+// the user has not passed --plugins, e.g. WithDefaultPlugins("managed", "local").
+func WithDefaultPlugins(providers ...string) Option {
+	return func(r *Resolver) {
+		if len(r.providers) == 0 {
+			parsed, err := ParseProviders(strings.Join(providers, ","))
+			if err == nil {
+				r.providers = parsed
+			}
+		}
+	}
+}
+
+// NewResolver creates a Resolver that looks up filename against handlers in
+// the order given by raw (the value of --plugins), applying opts if raw is
+// empty.
+func NewResolver(raw string, handlers map[string]ProviderHandler, opts ...Option) (*Resolver, error) {
+	providers, err := ParseProviders(raw)
+	if err != nil {
+		return nil, err
+	}
+
+	r := &Resolver{providers: providers, handlers: handlers}
+	for _, opt := range opts {
+		opt(r)
+	}
+
+	if len(r.providers) == 0 {
+		// nothing requested and no default configured: consult every known handler
+		for name := range handlers {
+			r.providers = append(r.providers, Provider{Name: name})
+		}
+	}
+
+	for _, p := range r.providers {
+		if _, ok := handlers[p.Name]; !ok {
+			return nil, fmt.Errorf("unknown plugin provider %q, must be one of %s", p.Name, knownProviderNames(handlers))
+		}
+	}
+	return r, nil
+}
+
+// ParseProviders parses a --plugins value such as "managed,local" or
+// "managed:v2" into an ordered, duplicate-free list of Providers. An empty
+// raw value returns an empty list rather than an error.
+func ParseProviders(raw string) ([]Provider, error) {
+	if raw == "" {
+		return nil, nil
+	}
+
+	seen := map[string]bool{}
+	answer := []Provider{}
+	for _, part := range strings.Split(raw, ",") {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			continue
+		}
+
+		name := part
+		version := ""
+		if idx := strings.IndexAny(part, ":@"); idx >= 0 {
+			name = part[:idx]
+			version = part[idx+1:]
+		}
+
+		if seen[name] {
+			return nil, fmt.Errorf("ambiguous --plugins value: provider %q specified more than once", name)
+		}
+		seen[name] = true
+		answer = append(answer, Provider{Name: name, Version: version})
+	}
+	return answer, nil
+}
+
+// Lookup tries each configured provider in order, returning the first
+// executable path found and recording which provider resolved it (see LastProvider).
+func (r *Resolver) Lookup(filename string) (string, error) {
+	var lastErr error
+	for _, p := range r.providers {
+		handler, ok := r.handlers[p.Name]
+		if !ok {
+			continue
+		}
+		path, err := handler.Lookup(filename)
+		if err != nil {
+			lastErr = err
+			continue
+		}
+		if path != "" {
+			r.lastProvider = p
+			return path, nil
+		}
+	}
+	if lastErr != nil {
+		return "", lastErr
+	}
+	return "", fmt.Errorf("no plugin provider found %s", filename)
+}
+
+// LastProvider returns the Provider that resolved the most recent successful
+// Lookup, so callers can persist it (see SaveLayoutKey) for later invocations
+// to default --plugins to.
+func (r *Resolver) LastProvider() Provider {
+	return r.lastProvider
+}
+
+func knownProviderNames(handlers map[string]ProviderHandler) string {
+	names := make([]string, 0, len(handlers))
+	for name := range handlers {
+		names = append(names, name)
+	}
+	return strings.Join(names, ", ")
+}
@@ -0,0 +1,82 @@
+/*
+Copyright 2018 The Kubernetes Authors & The Jenkins X Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/jenkins-x/jx/pkg/reports/junit"
+	"github.com/spf13/cobra"
+)
+
+// ReportOption is embedded by CommonOptions and provides the shared
+// --report flag used by long running commands (currently `jx compliance run`
+// and `jx diagnose`) to additionally emit a structured test report
+// describing each substep, so CI runners can pick up jx's own execution as
+// first-class test results.
+type ReportOption struct {
+	Report string
+}
+
+// addReportFlags registers the --report flag on cmd
+func (o *ReportOption) addReportFlags(cmd *cobra.Command) {
+	cmd.Flags().StringVarP(&o.Report, "report", "", "", "Emits a structured report of each substep, e.g. 'junit:report.xml'")
+}
+
+// CreateReportWriter parses --report and returns a junit.Writer for suite if
+// a junit report was requested, or nil if --report was not specified.
+// Callers must defer a call to Flush on the returned Writer so the report is
+// actually written once every substep has been recorded.
+func (o *ReportOption) CreateReportWriter(suite string) (*junit.Writer, error) {
+	if o.Report == "" {
+		return nil, nil
+	}
+
+	parts := strings.SplitN(o.Report, ":", 2)
+	if len(parts) != 2 || parts[0] != "junit" {
+		return nil, fmt.Errorf("unsupported --report value %q, expected 'junit:path.xml'", o.Report)
+	}
+	return junit.NewWriter(parts[1], suite), nil
+}
+
+// Flush writes w to disk if a report was requested (w is non-nil), doing
+// nothing otherwise. Callers should defer this right after CreateReportWriter:
+//
+//	w, err := o.CreateReportWriter("compliance")
+//	if err != nil { return err }
+//	defer o.Flush(w)
+func (o *ReportOption) Flush(w *junit.Writer) error {
+	if w == nil {
+		return nil
+	}
+	return w.Flush()
+}
+
+// ReportStep runs fn as a single substep named name, recording its outcome
+// to w if w is non-nil (i.e. --report was requested), and returns the error
+// fn returned either way. When reporting, fn's output is captured via o so
+// whatever it writes through o.Out/o.Err lands in the testcase's
+// system-out/system-err.
+func ReportStep(o *CommonOptions, w *junit.Writer, name string, fn func() error) error {
+	if w == nil {
+		return fn()
+	}
+	return w.Step(name, func() (string, string, error) {
+		return o.CaptureOutput(fn)
+	})
+}
@@ -0,0 +1,125 @@
+/*
+Copyright 2018 The Kubernetes Authors & The Jenkins X Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Command extract walks the source tree looking for i18n.T(...) calls and
+// writes out a messages.pot file listing every message ID found, so
+// translators can generate per-locale .po files from it. It is run via
+// `make i18n-extract`.
+package main
+
+import (
+	"flag"
+	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+func main() {
+	root := flag.String("root", ".", "root directory to scan for i18n.T() calls")
+	out := flag.String("out", "pkg/jx/i18n/locales/messages.pot", "path to write the extracted .pot file")
+	flag.Parse()
+
+	messageIDs := map[string]bool{}
+	fset := token.NewFileSet()
+
+	err := filepath.Walk(*root, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		if info.IsDir() || !strings.HasSuffix(path, ".go") {
+			return nil
+		}
+
+		file, err := parser.ParseFile(fset, path, nil, 0)
+		if err != nil {
+			// best effort: skip files that don't parse standalone
+			return nil
+		}
+
+		ast.Inspect(file, func(n ast.Node) bool {
+			call, ok := n.(*ast.CallExpr)
+			if !ok {
+				return true
+			}
+			if !isI18nCall(call.Fun) || len(call.Args) == 0 {
+				return true
+			}
+			lit, ok := call.Args[0].(*ast.BasicLit)
+			if !ok || lit.Kind != token.STRING {
+				return true
+			}
+			if id, err := strconv.Unquote(lit.Value); err == nil {
+				messageIDs[id] = true
+			}
+			return true
+		})
+		return nil
+	})
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "failed to scan %s: %v\n", *root, err)
+		os.Exit(1)
+	}
+
+	ids := make([]string, 0, len(messageIDs))
+	for id := range messageIDs {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+
+	if err := writePot(*out, ids); err != nil {
+		fmt.Fprintf(os.Stderr, "failed to write %s: %v\n", *out, err)
+		os.Exit(1)
+	}
+	fmt.Printf("wrote %d message IDs to %s\n", len(ids), *out)
+}
+
+// isI18nCall reports whether fun is a selector of the form i18n.T
+func isI18nCall(fun ast.Expr) bool {
+	sel, ok := fun.(*ast.SelectorExpr)
+	if !ok || sel.Sel.Name != "T" {
+		return false
+	}
+	ident, ok := sel.X.(*ast.Ident)
+	return ok && ident.Name == "i18n"
+}
+
+func writePot(path string, ids []string) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return err
+	}
+
+	f, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	fmt.Fprintln(f, `msgid ""`)
+	fmt.Fprintln(f, `msgstr ""`)
+	fmt.Fprintln(f)
+	for _, id := range ids {
+		fmt.Fprintf(f, "msgid %q\n", id)
+		fmt.Fprintln(f, `msgstr ""`)
+		fmt.Fprintln(f)
+	}
+	return nil
+}
@@ -0,0 +1,103 @@
+/*
+Copyright 2018 The Kubernetes Authors & The Jenkins X Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package i18n provides gettext-style localization of the strings shown by
+// jx's cobra commands (Short/Long/Example descriptions and user facing
+// error messages).
+package i18n
+
+import (
+	"os"
+	"strings"
+
+	"github.com/nicksnyder/go-i18n/i18n"
+	"github.com/nicksnyder/go-i18n/i18n/bundle"
+)
+
+const (
+	// DefaultLocale is used when neither $JX_LANG nor $LANG name a bundled locale.
+	DefaultLocale = "en-US"
+)
+
+var translateFunc i18n.TranslateFunc
+
+func init() {
+	Init(activeLocale())
+}
+
+// Init loads the translation catalog for locale, falling back to DefaultLocale
+// if locale has no bundled catalog.
+func Init(locale string) {
+	b := bundle.New()
+	entries, err := locales.ReadDir("locales")
+	if err != nil {
+		panic(err)
+	}
+	for _, entry := range entries {
+		name := entry.Name()
+		if !strings.HasSuffix(name, ".all.json") {
+			continue
+		}
+		data, err := locales.ReadFile("locales/" + name)
+		if err != nil {
+			panic(err)
+		}
+		if err := b.ParseTranslationFileBytes(name, data); err != nil {
+			panic(err)
+		}
+	}
+
+	fn, err := b.Tfunc(locale, DefaultLocale)
+	if err != nil {
+		fn, _ = b.Tfunc(DefaultLocale)
+	}
+	translateFunc = fn
+}
+
+// T translates messageID, optionally formatting it with args the same way
+// go-i18n's TranslateFunc does. Command Short/Long/Example strings and
+// error messages should always be passed through T so they can be
+// localized without touching call sites again.
+func T(messageID string, args ...interface{}) string {
+	if translateFunc == nil {
+		return messageID
+	}
+	return translateFunc(messageID, args...)
+}
+
+// activeLocale resolves the locale to use at startup: $JX_LANG takes
+// precedence over $LANG, falling back to DefaultLocale. Both are normalized
+// from POSIX form (e.g. "es_ES.UTF-8") to the bundle key form ("es-ES") a
+// catalog is actually registered under.
+func activeLocale() string {
+	if l := os.Getenv("JX_LANG"); l != "" {
+		return normalizeLocale(l)
+	}
+	if l := os.Getenv("LANG"); l != "" {
+		return normalizeLocale(l)
+	}
+	return DefaultLocale
+}
+
+// normalizeLocale strips the encoding/modifier suffix a POSIX locale name
+// carries (e.g. the ".UTF-8" in "es_ES.UTF-8", or an "@euro" modifier) and
+// swaps "_" for "-", so "es_ES.UTF-8" becomes "es-ES".
+func normalizeLocale(locale string) string {
+	if idx := strings.IndexAny(locale, ".@"); idx >= 0 {
+		locale = locale[:idx]
+	}
+	return strings.Replace(locale, "_", "-", -1)
+}
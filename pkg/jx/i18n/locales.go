@@ -0,0 +1,28 @@
+/*
+Copyright 2018 The Kubernetes Authors & The Jenkins X Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package i18n
+
+import "embed"
+
+// locales embeds locales/*.all.json directly into the binary so jx ships as
+// a single executable with no runtime dependency on the files on disk, while
+// still loading the catalogs straight from the same files translators edit --
+// there is no longer a second, hand-copied source of truth to fall out of
+// sync with them.
+//
+//go:embed locales/*.all.json
+var locales embed.FS
@@ -0,0 +1,142 @@
+/*
+Copyright 2018 The Kubernetes Authors & The Jenkins X Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package junit writes JUnit XML test-suite reports so CI runners such as
+// Jenkins can pick up jx's own execution as first-class test results
+// instead of scraping logs.
+package junit
+
+import (
+	"encoding/xml"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// TestCase is a single substep of a command, reported as a <testcase>.
+type TestCase struct {
+	XMLName   xml.Name `xml:"testcase"`
+	Name      string   `xml:"name,attr"`
+	ClassName string   `xml:"classname,attr"`
+	Time      float64  `xml:"time,attr"`
+	Failure   *Failure `xml:"failure,omitempty"`
+	Skipped   *Skipped `xml:"skipped,omitempty"`
+	SystemOut string   `xml:"system-out,omitempty"`
+	SystemErr string   `xml:"system-err,omitempty"`
+}
+
+// Failure records why a TestCase failed.
+type Failure struct {
+	Message string `xml:"message,attr"`
+	Content string `xml:",chardata"`
+}
+
+// Skipped marks a TestCase as skipped.
+type Skipped struct {
+	Message string `xml:"message,attr,omitempty"`
+}
+
+// TestSuite is a named group of TestCases, written out as a single file.
+type TestSuite struct {
+	XMLName   xml.Name   `xml:"testsuite"`
+	Name      string     `xml:"name,attr"`
+	Tests     int        `xml:"tests,attr"`
+	Failures  int        `xml:"failures,attr"`
+	Skipped   int        `xml:"skipped,attr"`
+	Time      float64    `xml:"time,attr"`
+	TestCases []TestCase `xml:"testcase"`
+}
+
+// Writer accumulates TestCases for a single command invocation and writes
+// them out as a JUnit XML TestSuite when Flush is called.
+type Writer struct {
+	Path      string
+	Suite     string
+	testCases []TestCase
+}
+
+// NewWriter creates a Writer that will write a <testsuite name="suite"> to path on Flush.
+func NewWriter(path string, suite string) *Writer {
+	return &Writer{Path: path, Suite: suite}
+}
+
+// Step records the outcome of a single substep as a testcase, timing fn and
+// recording the error it returns (if any) as a <failure>. fn must capture
+// whatever it writes and return it as stdout/stderr itself: only the caller
+// knows where a step's real output goes (e.g. a CommonOptions.Out/Err pair),
+// so this package can't intercept it generically.
+func (w *Writer) Step(name string, fn func() (stdout string, stderr string, err error)) error {
+	start := time.Now()
+	out, errOut, err := fn()
+	elapsed := time.Since(start).Seconds()
+
+	tc := TestCase{
+		Name:      name,
+		ClassName: w.Suite,
+		Time:      elapsed,
+		SystemOut: out,
+		SystemErr: errOut,
+	}
+	if err != nil {
+		tc.Failure = &Failure{Message: err.Error(), Content: err.Error()}
+	}
+	w.testCases = append(w.testCases, tc)
+	return err
+}
+
+// Skip records a substep as skipped without running it.
+func (w *Writer) Skip(name string, reason string) {
+	w.testCases = append(w.testCases, TestCase{
+		Name:      name,
+		ClassName: w.Suite,
+		Skipped:   &Skipped{Message: reason},
+	})
+}
+
+// Flush writes the accumulated TestCases to Path as JUnit XML.
+func (w *Writer) Flush() error {
+	suite := TestSuite{
+		Name:      w.Suite,
+		TestCases: w.testCases,
+	}
+	for _, tc := range suite.TestCases {
+		suite.Tests++
+		suite.Time += tc.Time
+		if tc.Failure != nil {
+			suite.Failures++
+		}
+		if tc.Skipped != nil {
+			suite.Skipped++
+		}
+	}
+
+	if err := os.MkdirAll(filepath.Dir(w.Path), 0755); err != nil {
+		return err
+	}
+
+	f, err := os.Create(w.Path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	encoder := xml.NewEncoder(f)
+	encoder.Indent("", "  ")
+	if _, err := f.WriteString(xml.Header); err != nil {
+		return err
+	}
+	return encoder.Encode(suite)
+}
@@ -0,0 +1,138 @@
+/*
+Copyright 2018 The Kubernetes Authors & The Jenkins X Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package junit
+
+import (
+	"errors"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestWriterFlushWritesPassedSkippedAndFailedCases(t *testing.T) {
+	dir, err := ioutil.TempDir("", "junit-test")
+	if err != nil {
+		t.Fatalf("failed to create temp dir: %v", err)
+	}
+	defer os.RemoveAll(dir)
+
+	path := filepath.Join(dir, "report.xml")
+	w := NewWriter(path, "my-suite")
+
+	if err := w.Step("passes", func() (string, string, error) { return "", "", nil }); err != nil {
+		t.Errorf("expected passing step to return nil, got %v", err)
+	}
+
+	wantErr := errors.New("boom")
+	if err := w.Step("fails", func() (string, string, error) { return "", "", wantErr }); err != wantErr {
+		t.Errorf("expected Step to return the underlying error, got %v", err)
+	}
+
+	w.Skip("skipped", "not applicable")
+
+	if err := w.Flush(); err != nil {
+		t.Fatalf("Flush returned an error: %v", err)
+	}
+
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		t.Fatalf("expected report file to exist: %v", err)
+	}
+
+	content := string(data)
+	for _, want := range []string{
+		`tests="3"`,
+		`failures="1"`,
+		`skipped="1"`,
+		`name="passes"`,
+		`name="fails"`,
+		`name="skipped"`,
+		`message="boom"`,
+	} {
+		if !contains(content, want) {
+			t.Errorf("expected report to contain %q, got:\n%s", want, content)
+		}
+	}
+}
+
+func TestWriterStepWritesCapturedOutputToTheTestCase(t *testing.T) {
+	dir, err := ioutil.TempDir("", "junit-test")
+	if err != nil {
+		t.Fatalf("failed to create temp dir: %v", err)
+	}
+	defer os.RemoveAll(dir)
+
+	path := filepath.Join(dir, "report.xml")
+	w := NewWriter(path, "my-suite")
+
+	if err := w.Step("captured", func() (string, string, error) {
+		return "hello from stdout", "hello from stderr", nil
+	}); err != nil {
+		t.Errorf("expected passing step to return nil, got %v", err)
+	}
+
+	if err := w.Flush(); err != nil {
+		t.Fatalf("Flush returned an error: %v", err)
+	}
+
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		t.Fatalf("expected report file to exist: %v", err)
+	}
+
+	content := string(data)
+	for _, want := range []string{
+		`<system-out>hello from stdout</system-out>`,
+		`<system-err>hello from stderr</system-err>`,
+	} {
+		if !contains(content, want) {
+			t.Errorf("expected report to contain %q, got:\n%s", want, content)
+		}
+	}
+}
+
+func TestWriterFlushCreatesMissingDirectories(t *testing.T) {
+	dir, err := ioutil.TempDir("", "junit-test")
+	if err != nil {
+		t.Fatalf("failed to create temp dir: %v", err)
+	}
+	defer os.RemoveAll(dir)
+
+	path := filepath.Join(dir, "nested", "reports", "report.xml")
+	w := NewWriter(path, "my-suite")
+
+	if err := w.Flush(); err != nil {
+		t.Fatalf("Flush returned an error: %v", err)
+	}
+	if _, err := os.Stat(path); err != nil {
+		t.Errorf("expected %s to exist: %v", path, err)
+	}
+}
+
+func contains(haystack string, needle string) bool {
+	return len(needle) == 0 || indexOf(haystack, needle) >= 0
+}
+
+func indexOf(haystack string, needle string) int {
+	for i := 0; i+len(needle) <= len(haystack); i++ {
+		if haystack[i:i+len(needle)] == needle {
+			return i
+		}
+	}
+	return -1
+}